@@ -0,0 +1,23 @@
+package controller
+
+import "net/http"
+
+// pendingConfigApplier is satisfied by GameController (see apiServerReplay.go for why these
+// Consumer-facing interfaces are kept narrow).
+type pendingConfigApplier interface {
+	ApplyPendingConfig()
+}
+
+// RegisterConfigHandlers wires the explicit "apply now" trigger for a pending config reload onto mux.
+func (a *ApiServer) RegisterConfigHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/api/config/applyPending", a.handleApplyPendingConfig)
+}
+
+func (a *ApiServer) handleApplyPendingConfig(w http.ResponseWriter, r *http.Request) {
+	applier, ok := a.Consumer.(pendingConfigApplier)
+	if !ok {
+		http.Error(w, "config reload not supported", http.StatusNotImplemented)
+		return
+	}
+	applier.ApplyPendingConfig()
+}
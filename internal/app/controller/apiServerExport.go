@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// stateExporter and stateImporter are satisfied by GameController (see apiServerReplay.go
+// for why these Consumer-facing interfaces are kept narrow).
+type stateExporter interface {
+	Export() (*StateExport, error)
+}
+
+type stateImporter interface {
+	Import(*StateExport) error
+}
+
+// RegisterExportHandlers wires /api/export and /api/import onto mux.
+func (a *ApiServer) RegisterExportHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/api/export", a.handleExport)
+	mux.HandleFunc("/api/import", a.handleImport)
+}
+
+func (a *ApiServer) handleExport(w http.ResponseWriter, r *http.Request) {
+	exporter, ok := a.Consumer.(stateExporter)
+	if !ok {
+		http.Error(w, "export not supported", http.StatusNotImplemented)
+		return
+	}
+
+	export, err := exporter.Export()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(export); err != nil {
+		log.Print("Could not write export response ", err)
+	}
+}
+
+func (a *ApiServer) handleImport(w http.ResponseWriter, r *http.Request) {
+	importer, ok := a.Consumer.(stateImporter)
+	if !ok {
+		http.Error(w, "import not supported", http.StatusNotImplemented)
+		return
+	}
+
+	var export StateExport
+	if err := json.NewDecoder(r.Body).Decode(&export); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := importer.Import(&export); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+}
@@ -0,0 +1,13 @@
+package controller
+
+import "net/http"
+
+// RegisterHandlers wires every endpoint added by the apiServer*.go files onto mux. Call it
+// once, with the mux the API HTTP server is actually listening on, before serving it -
+// none of the Register*Handlers methods below are hooked up to anything on their own.
+func (a *ApiServer) RegisterHandlers(mux *http.ServeMux) {
+	a.RegisterExportHandlers(mux)
+	a.RegisterReplayHandlers(mux)
+	a.RegisterJournalHandlers(mux)
+	a.RegisterConfigHandlers(mux)
+}
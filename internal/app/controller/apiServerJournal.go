@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// journalController is satisfied by GameController (see apiServerReplay.go for why these
+// Consumer-facing interfaces are kept narrow).
+type journalController interface {
+	Undo()
+	Redo(n int)
+	JumpTo(seq uint64)
+}
+
+// RegisterJournalHandlers wires undo/redo/jump-to onto mux.
+func (a *ApiServer) RegisterJournalHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/api/journal/undo", a.handleJournalUndo)
+	mux.HandleFunc("/api/journal/redo", a.handleJournalRedo)
+	mux.HandleFunc("/api/journal/jumpTo", a.handleJournalJumpTo)
+}
+
+func (a *ApiServer) handleJournalUndo(w http.ResponseWriter, r *http.Request) {
+	journal, ok := a.Consumer.(journalController)
+	if !ok {
+		http.Error(w, "journal not supported", http.StatusNotImplemented)
+		return
+	}
+	journal.Undo()
+}
+
+func (a *ApiServer) handleJournalRedo(w http.ResponseWriter, r *http.Request) {
+	journal, ok := a.Consumer.(journalController)
+	if !ok {
+		http.Error(w, "journal not supported", http.StatusNotImplemented)
+		return
+	}
+
+	n := 1
+	if raw := r.URL.Query().Get("n"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "invalid n: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		n = parsed
+	}
+	journal.Redo(n)
+}
+
+func (a *ApiServer) handleJournalJumpTo(w http.ResponseWriter, r *http.Request) {
+	journal, ok := a.Consumer.(journalController)
+	if !ok {
+		http.Error(w, "journal not supported", http.StatusNotImplemented)
+		return
+	}
+
+	seq, err := strconv.ParseUint(r.URL.Query().Get("seq"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid seq: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	journal.JumpTo(seq)
+}
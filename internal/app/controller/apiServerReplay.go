@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// replayController is satisfied by GameController. Every apiServer*.go file declares its
+// own small Consumer-facing interface like this one instead of sharing a single fat
+// interface, so none of them needs to know ApiServer.Consumer's concrete type.
+type replayController interface {
+	Pause()
+	Resume()
+	Step()
+	Seek(t int64)
+}
+
+// RegisterReplayHandlers wires the replay pause/step/seek controls onto mux.
+func (a *ApiServer) RegisterReplayHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/api/replay/pause", a.handleReplayPause)
+	mux.HandleFunc("/api/replay/resume", a.handleReplayResume)
+	mux.HandleFunc("/api/replay/step", a.handleReplayStep)
+	mux.HandleFunc("/api/replay/seek", a.handleReplaySeek)
+}
+
+func (a *ApiServer) handleReplayPause(w http.ResponseWriter, r *http.Request) {
+	replay, ok := a.Consumer.(replayController)
+	if !ok {
+		http.Error(w, "replay not supported", http.StatusNotImplemented)
+		return
+	}
+	replay.Pause()
+}
+
+func (a *ApiServer) handleReplayResume(w http.ResponseWriter, r *http.Request) {
+	replay, ok := a.Consumer.(replayController)
+	if !ok {
+		http.Error(w, "replay not supported", http.StatusNotImplemented)
+		return
+	}
+	replay.Resume()
+}
+
+func (a *ApiServer) handleReplayStep(w http.ResponseWriter, r *http.Request) {
+	replay, ok := a.Consumer.(replayController)
+	if !ok {
+		http.Error(w, "replay not supported", http.StatusNotImplemented)
+		return
+	}
+	replay.Step()
+}
+
+func (a *ApiServer) handleReplaySeek(w http.ResponseWriter, r *http.Request) {
+	replay, ok := a.Consumer.(replayController)
+	if !ok {
+		http.Error(w, "replay not supported", http.StatusNotImplemented)
+		return
+	}
+
+	t, err := strconv.ParseInt(r.URL.Query().Get("t"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid t: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	replay.Seek(t)
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchConfig watches configFileName for changes and reloads it on the fly.
+func (r *GameController) watchConfig() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("Could not start config watcher: %v", err)
+		return
+	}
+
+	if err := watcher.Add(configFileName); err != nil {
+		log.Printf("Could not watch %v: %v", configFileName, err)
+		return
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					r.reloadConfig()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Config watcher error: %v", err)
+			}
+		}
+	}()
+}
+
+// reloadConfig re-reads configFileName, applies the fields that are safe to change
+// mid-game (publisher address, stage durations, timeout/card parameters) right away,
+// and stashes anything else as a pending config until ApplyPendingConfig is called.
+func (r *GameController) reloadConfig() {
+	newConfig, err := LoadConfig(configFileName)
+	if err != nil {
+		log.Printf("Could not reload config: %v", err)
+		return
+	}
+
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	diff := diffConfig(r.Config, newConfig)
+	if diff == "" {
+		return
+	}
+	log.Printf("Config file changed:\n%v", diff)
+
+	r.applySafeConfig(newConfig)
+
+	safeApplied := r.Config
+	if !reflect.DeepEqual(safeApplied, newConfig) {
+		r.pendingConfig = &newConfig
+		log.Println("Some config changes are not safe to apply live; call ApplyPendingConfig to take effect now")
+	}
+}
+
+// applySafeConfig updates the publisher address and stage/timeout/card durations in place.
+// It never touches r.State, so already-elapsed times survive the reload. Callers must hold
+// stateMu, since Tick and processEvent read r.Config/r.StageTimes without their own locking
+// around those particular fields.
+func (r *GameController) applySafeConfig(newConfig Config) {
+	if newConfig.Publish.Address != r.Config.Publish.Address {
+		r.Publisher = loadPublisher(newConfig)
+	}
+	r.Config.Publish = newConfig.Publish
+	r.Config.Normal = newConfig.Normal
+	r.Config.Overtime = newConfig.Overtime
+	r.loadStages()
+}
+
+// ApplyPendingConfig applies the risky part of the last reloaded config immediately,
+// instead of waiting for a restart. This is triggered by an explicit "apply now" event
+// on the ApiServer so referees are never surprised by a config change mid-game.
+func (r *GameController) ApplyPendingConfig() {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	if r.pendingConfig == nil {
+		return
+	}
+	r.Config = *r.pendingConfig
+	r.pendingConfig = nil
+	r.loadStages()
+	log.Println("Applied pending config changes")
+}
+
+// diffConfig returns a human-readable summary of the fields that changed between old and new
+func diffConfig(old, new Config) string {
+	diff := ""
+	if old.Publish.Address != new.Publish.Address {
+		diff += fmt.Sprintf("publish.address: %v -> %v\n", old.Publish.Address, new.Publish.Address)
+	}
+	if old.Normal != new.Normal {
+		diff += fmt.Sprintf("normal: %+v -> %+v\n", old.Normal, new.Normal)
+	}
+	if old.Overtime != new.Overtime {
+		diff += fmt.Sprintf("overtime: %+v -> %+v\n", old.Overtime, new.Overtime)
+	}
+	rest := new
+	rest.Publish, rest.Normal, rest.Overtime = old.Publish, old.Normal, old.Overtime
+	if !reflect.DeepEqual(old, rest) {
+		diff += "other config fields also changed\n"
+	}
+	return diff
+}
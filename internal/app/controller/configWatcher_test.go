@@ -0,0 +1,37 @@
+package controller
+
+import "testing"
+
+// TestDiffConfigNoChange checks that two identical configs produce no diff, since
+// reloadConfig uses an empty diff to decide there is nothing to apply.
+func TestDiffConfigNoChange(t *testing.T) {
+	a := Config{}
+	b := Config{}
+	if diff := diffConfig(a, b); diff != "" {
+		t.Fatalf("expected no diff for identical configs, got %q", diff)
+	}
+}
+
+// TestDiffConfigPublishAddressChange checks that a changed publish address is reported,
+// since that's the field applySafeConfig is expected to pick up live.
+func TestDiffConfigPublishAddressChange(t *testing.T) {
+	a := Config{}
+	b := Config{}
+	b.Publish.Address = "224.5.23.2:10003"
+
+	diff := diffConfig(a, b)
+	if diff == "" {
+		t.Fatal("expected a diff when publish.address changes")
+	}
+}
+
+// TestApplyPendingConfigWithoutPending checks that ApplyPendingConfig is a no-op when
+// reloadConfig never stashed a pending config, so hitting the API endpoint speculatively
+// can't panic or clobber the running config.
+func TestApplyPendingConfigWithoutPending(t *testing.T) {
+	r := &GameController{Config: Config{}}
+	r.ApplyPendingConfig()
+	if r.pendingConfig != nil {
+		t.Fatal("pendingConfig should remain nil")
+	}
+}
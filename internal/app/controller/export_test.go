@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+// TestImportRejectsNewerVersion checks that Import refuses a StateExport produced by a
+// newer release than this binary understands, instead of silently loading fields it
+// can't remap correctly.
+func TestImportRejectsNewerVersion(t *testing.T) {
+	r := &GameController{State: &State{}}
+	err := r.Import(&StateExport{Version: stateExportVersion + 1})
+	if err == nil {
+		t.Fatal("expected an error importing a newer export version")
+	}
+}
+
+// TestExportImportRoundTrip checks that exporting and then importing into a fresh
+// GameController reproduces the journal tail, and that the importing GameController does
+// not end up sharing the exporting GameController's State pointer.
+func TestExportImportRoundTrip(t *testing.T) {
+	state := State{}
+	source := &GameController{
+		State:          &state,
+		Config:         Config{},
+		StageTimes:     map[Stage]time.Duration{},
+		journalEntries: []JournalEntry{{Seq: 1, Event: Event{}}},
+	}
+
+	export, err := source.Export()
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	target := &GameController{State: &State{}}
+	if err := target.Import(export); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if len(target.journalEntries) != 1 || target.journalEntries[0].Seq != 1 {
+		t.Fatalf("journal tail not imported, got %+v", target.journalEntries)
+	}
+	if target.State == source.State {
+		t.Fatal("Import should not alias the exporting GameController's State")
+	}
+}
@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// journalSnapshotInterval controls how often an in-memory state snapshot is kept while
+// folding the journal forward, so Undo/Redo/JumpTo only ever replay a bounded number of events.
+const journalSnapshotInterval = 100
+
+// JournalEntry is a single line of an append-only event journal file. Unlike the
+// state-history NDJSON log, the journal never stores full State snapshots inline -
+// state is always reconstructed by folding events over an initial State.
+type JournalEntry struct {
+	Seq   uint64 `json:"seq"`
+	Event Event  `json:"event"`
+}
+
+// journalRecord is the on-disk shape of a single journal line. It is either a normal
+// entry (Seq/Event set) or a truncate marker (TruncateToSeq set) written when Undo is
+// followed by a new event, so a diverging branch never silently resurrects the discarded
+// suffix on replay. journalSeq is never reused, so every entry appended after a marker
+// naturally sorts after it by Seq.
+type journalRecord struct {
+	Seq           uint64  `json:"seq,omitempty"`
+	Event         *Event  `json:"event,omitempty"`
+	TruncateToSeq *uint64 `json:"truncateToSeq,omitempty"`
+}
+
+// journalSnapshot is a compact checkpoint of State at a given journal cursor.
+type journalSnapshot struct {
+	Cursor uint64 `json:"cursor"`
+	State  State  `json:"state"`
+}
+
+func journalFileName() string {
+	return logDir + "/events_" + time.Now().Format("2006-01-02") + ".jsonl"
+}
+
+// readJournalEntries reads every events_*.jsonl file in dir, in chronological (filename) order,
+// applying any truncate markers along the way so a branch written after an Undo does not
+// resurrect the discarded suffix it replaced.
+func readJournalEntries(dir string) ([]JournalEntry, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "events_*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var entries []JournalEntry
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+		for scanner.Scan() {
+			var rec journalRecord
+			if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+				log.Println("Could not parse journal entry:", scanner.Text(), err)
+				continue
+			}
+			if rec.TruncateToSeq != nil {
+				entries = truncateEntriesBySeq(entries, *rec.TruncateToSeq)
+				continue
+			}
+			if rec.Event == nil {
+				log.Println("Could not parse journal entry:", scanner.Text())
+				continue
+			}
+			entries = append(entries, JournalEntry{Seq: rec.Seq, Event: *rec.Event})
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// truncateEntriesBySeq drops every entry with a Seq greater than seq, as if it had never
+// been appended. Entries are always appended in increasing Seq order, so this is a suffix cut.
+func truncateEntriesBySeq(entries []JournalEntry, seq uint64) []JournalEntry {
+	idx := sort.Search(len(entries), func(i int) bool {
+		return entries[i].Seq > seq
+	})
+	return entries[:idx]
+}
+
+// appendJournalEntry assigns the next sequence number to event, writes it to the journal
+// file and the in-memory cursor, dropping any redo tail left over from a previous Undo. If
+// the cursor sits behind the end of the journal, a truncate marker is written first so the
+// discarded tail is also dropped on the next replay from disk, not just in memory. Callers
+// must hold stateMu.
+func (r *GameController) appendJournalEntry(event Event) {
+	if r.journalCursor < uint64(len(r.journalEntries)) {
+		var truncateToSeq uint64
+		if r.journalCursor > 0 {
+			truncateToSeq = r.journalEntries[r.journalCursor-1].Seq
+		}
+		r.writeTruncateMarker(truncateToSeq)
+
+		r.journalEntries = r.journalEntries[:r.journalCursor]
+		r.journalSnapshots = truncateJournalSnapshots(r.journalSnapshots, r.journalCursor)
+	}
+
+	r.journalSeq++
+	entry := JournalEntry{Seq: r.journalSeq, Event: event}
+	r.journalEntries = append(r.journalEntries, entry)
+	r.journalCursor++
+
+	jsonEntry, err := json.Marshal(entry)
+	if err != nil {
+		log.Print("Can not marshal journal entry ", err)
+		return
+	}
+	r.journalFile.Write(jsonEntry)
+	r.journalFile.WriteString("\n")
+	r.journalFile.Sync()
+
+	if r.journalCursor%journalSnapshotInterval == 0 {
+		// Callers hold stateMu here (see appendJournalEntry's doc comment), so r.State is
+		// safe to read, but it must be deep-cloned: a shallow copy would keep sharing
+		// TeamState's map and *TeamInfo entries with the live state the tick goroutine
+		// keeps mutating, silently corrupting this "frozen" checkpoint.
+		stateCopy, err := cloneState(r.State)
+		if err != nil {
+			log.Print("Can not clone state for journal snapshot ", err)
+		} else {
+			r.journalSnapshots = append(r.journalSnapshots, journalSnapshot{Cursor: r.journalCursor, State: stateCopy})
+		}
+	}
+}
+
+// writeTruncateMarker appends a tombstone record to the journal file recording that every
+// entry with a higher Seq than seq is discarded, so readJournalEntries rebuilds the same
+// entries the in-memory cursor sees after Undo.
+func (r *GameController) writeTruncateMarker(seq uint64) {
+	rec := journalRecord{TruncateToSeq: &seq}
+	jsonRec, err := json.Marshal(rec)
+	if err != nil {
+		log.Print("Can not marshal journal truncate marker ", err)
+		return
+	}
+	r.journalFile.Write(jsonRec)
+	r.journalFile.WriteString("\n")
+	r.journalFile.Sync()
+}
+
+func truncateJournalSnapshots(snapshots []journalSnapshot, cursor uint64) []journalSnapshot {
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].Cursor <= cursor {
+			return snapshots[:i+1]
+		}
+	}
+	return nil
+}
+
+// Undo moves the journal cursor one event back and refolds the state.
+func (r *GameController) Undo() {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	if r.journalCursor == 0 {
+		return
+	}
+	r.journalCursor--
+	r.refold()
+}
+
+// Redo moves the journal cursor up to n events forward and refolds the state.
+func (r *GameController) Redo(n int) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	target := r.journalCursor + uint64(n)
+	if max := uint64(len(r.journalEntries)); target > max {
+		target = max
+	}
+	r.journalCursor = target
+	r.refold()
+}
+
+// JumpTo moves the journal cursor to just after the entry with the given sequence number
+// and refolds the state.
+func (r *GameController) JumpTo(seq uint64) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	idx := sort.Search(len(r.journalEntries), func(i int) bool {
+		return r.journalEntries[i].Seq > seq
+	})
+	r.journalCursor = uint64(idx)
+	r.refold()
+}
+
+// refold reconstructs r.State by starting from the closest preceding in-memory snapshot
+// (or a fresh State if there is none) and replaying the journal up to journalCursor.
+// Callers must hold stateMu.
+func (r *GameController) refold() {
+	*r.State = *NewState(r.Config)
+	start := uint64(0)
+
+	for i := len(r.journalSnapshots) - 1; i >= 0; i-- {
+		if r.journalSnapshots[i].Cursor <= r.journalCursor {
+			// Clone rather than assign the snapshot's State directly: a shallow assignment
+			// would leave r.State.TeamState pointing at the very map stored in
+			// r.journalSnapshots[i], so replaying events below would mutate that "frozen"
+			// snapshot in place and corrupt it for the next Undo/Redo/JumpTo that reuses it.
+			stateCopy, err := cloneState(&r.journalSnapshots[i].State)
+			if err != nil {
+				log.Print("Can not clone journal snapshot state ", err)
+				stateCopy = r.journalSnapshots[i].State
+			}
+			*r.State = stateCopy
+			start = r.journalSnapshots[i].Cursor
+			break
+		}
+	}
+
+	for i := start; i < r.journalCursor; i++ {
+		event := r.journalEntries[i].Event
+		if err := processEvent(&event); err != nil {
+			log.Println("Could not replay journal event:", event, err)
+		}
+	}
+
+	r.Publish(nil)
+}
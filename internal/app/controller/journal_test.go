@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestAppendJournalEntryPersistsUndoDivergence reproduces append -> undo -> append a new,
+// diverging event -> reload from disk, and checks that the reload reconstructs the branch
+// that was actually live, not the discarded tail underneath it. Regression test for the
+// on-disk journal silently resurrecting undone entries after a restart.
+func TestAppendJournalEntryPersistsUndoDivergence(t *testing.T) {
+	dir := t.TempDir()
+	f, err := os.OpenFile(filepath.Join(dir, "events_2026-07-27.jsonl"), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		t.Fatalf("could not open journal file: %v", err)
+	}
+	defer f.Close()
+
+	r := &GameController{journalFile: f}
+	r.appendJournalEntry(Event{})
+	r.appendJournalEntry(Event{})
+	r.appendJournalEntry(Event{})
+
+	// Undo the last entry without refolding (refold needs processEvent, which is outside
+	// this file's scope), then append a new event in its place, same as a live Undo+OnNewEvent.
+	r.journalCursor--
+	r.appendJournalEntry(Event{})
+
+	if len(r.journalEntries) != 3 {
+		t.Fatalf("expected 3 in-memory entries after undo+append, got %v", len(r.journalEntries))
+	}
+
+	entries, err := readJournalEntries(dir)
+	if err != nil {
+		t.Fatalf("readJournalEntries: %v", err)
+	}
+	if len(entries) != len(r.journalEntries) {
+		t.Fatalf("reload reconstructed %v entries, want %v", len(entries), len(r.journalEntries))
+	}
+	for i, entry := range entries {
+		if entry.Seq != r.journalEntries[i].Seq {
+			t.Errorf("entry %d: disk seq %v, in-memory seq %v", i, entry.Seq, r.journalEntries[i].Seq)
+		}
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"github.com/g3force/ssl-game-controller/pkg/timer"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"sync"
 	"time"
 )
 
 const logDir = "logs"
-const lastStateFileName = logDir + "/lastState.json"
+const snapshotFileName = logDir + "/lastState.json"
 const configFileName = "config/ssl-game-controller.yaml"
+const apiAddress = ":8081"
 
 var RefBox = NewRefBox()
 
@@ -20,13 +23,20 @@ type GameController struct {
 	State            *State
 	timer            timer.Timer
 	MatchTimeStart   time.Time
-	StateHistory     []State
 	Config           Config
 	stateHistoryFile *os.File
-	lastStateFile    *os.File
+	snapshotFile     *os.File
 	StageTimes       map[Stage]time.Duration
 	Publisher        Publisher
 	ApiServer        ApiServer
+	replay           *replayControl
+	pendingConfig    *Config
+	journalFile      *os.File
+	journalEntries   []JournalEntry
+	journalCursor    uint64
+	journalSeq       uint64
+	journalSnapshots []journalSnapshot
+	stateMu          sync.Mutex
 }
 
 // NewRefBox creates a new RefBox
@@ -51,14 +61,27 @@ func (r *GameController) Run() (err error) {
 	r.openStateFiles()
 	r.readLastState()
 	r.loadStages()
+	r.watchConfig()
+	r.watchTeams()
 	r.timer.Start()
 
+	mux := http.NewServeMux()
+	r.ApiServer.RegisterHandlers(mux)
+	go func() {
+		if err := http.ListenAndServe(apiAddress, mux); err != nil {
+			log.Printf("Api server stopped: %v", err)
+		}
+	}()
+
 	go func() {
 		if r.stateHistoryFile != nil {
 			defer r.stateHistoryFile.Close()
 		}
-		if r.lastStateFile != nil {
-			defer r.lastStateFile.Close()
+		if r.snapshotFile != nil {
+			defer r.snapshotFile.Close()
+		}
+		if r.journalFile != nil {
+			defer r.journalFile.Close()
 		}
 		for {
 			r.timer.WaitTillNextFullSecond()
@@ -69,6 +92,23 @@ func (r *GameController) Run() (err error) {
 	return nil
 }
 
+// cloneState returns a deep copy of state via a JSON round-trip. State holds maps and
+// pointers (TeamState's *TeamInfo entries in particular) that the tick goroutine keeps
+// mutating in place, so anything that needs to freeze a point-in-time copy - Export, or a
+// journal snapshot - must clone rather than take a shallow struct copy, or it ends up
+// aliasing state that changes out from under it.
+func cloneState(state *State) (State, error) {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return State{}, err
+	}
+	var clone State
+	if err := json.Unmarshal(b, &clone); err != nil {
+		return State{}, err
+	}
+	return clone, nil
+}
+
 func loadPublisher(config Config) Publisher {
 	publisher, err := NewPublisher(config.Publish.Address)
 	if err != nil {
@@ -92,33 +132,71 @@ func (r *GameController) openStateFiles() {
 		log.Fatal("Can not open state history log file", err)
 	}
 	r.stateHistoryFile = f
-	f, err = os.OpenFile(lastStateFileName, os.O_RDWR|os.O_CREATE, 0600)
+	f, err = os.OpenFile(snapshotFileName, os.O_RDWR|os.O_CREATE, 0600)
 	if err != nil {
-		log.Fatal("Can not open last state file", err)
+		log.Fatal("Can not open snapshot file", err)
 	}
-	r.lastStateFile = f
+	r.snapshotFile = f
+	f, err = os.OpenFile(journalFileName(), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		log.Fatal("Can not open event journal file", err)
+	}
+	r.journalFile = f
 }
 
+// readLastState loads the newest on-disk snapshot, then replays the journal suffix
+// written after it, so a restart only ever re-processes the events since the last snapshot.
 func (r *GameController) readLastState() {
+	startCursor, ok := r.readDiskSnapshot()
+	if !ok {
+		r.State = NewState(r.Config)
+	}
+
+	entries, err := readJournalEntries(logDir)
+	if err != nil {
+		log.Fatal("Could not read event journal ", err)
+	}
+	r.journalEntries = entries
+
+	for i := startCursor; i < uint64(len(entries)); i++ {
+		event := entries[i].Event
+		if err := processEvent(&event); err != nil {
+			log.Println("Could not replay journal event:", event, err)
+		}
+	}
+	r.journalCursor = uint64(len(entries))
+	if n := len(entries); n > 0 {
+		r.journalSeq = entries[n-1].Seq
+	}
+}
+
+func (r *GameController) readDiskSnapshot() (cursor uint64, ok bool) {
 	bufSize := 10000
 	b := make([]byte, bufSize)
-	n, err := r.lastStateFile.Read(b)
+	n, err := r.snapshotFile.Read(b)
 	if err != nil && err != io.EOF {
-		log.Fatal("Could not read from last state file ", err)
+		log.Fatal("Could not read from snapshot file ", err)
 	}
 	if n == bufSize {
 		log.Fatal("Buffer size too small")
 	}
-	if n > 0 {
-		err = json.Unmarshal(b[:n], RefBox.State)
-		if err != nil {
-			log.Fatalf("Could not read last state: %v %v", string(b), err)
-		}
+	if n == 0 {
+		return 0, false
+	}
+
+	var snapshot journalSnapshot
+	if err := json.Unmarshal(b[:n], &snapshot); err != nil {
+		log.Fatalf("Could not read snapshot: %v %v", string(b), err)
 	}
+	r.State = &snapshot.State
+	return snapshot.Cursor, true
 }
 
 // Tick updates the times of the state and removes cards, if necessary
 func (r *GameController) Tick() {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
 	delta := r.timer.Delta()
 	updateTimes(r, delta)
 
@@ -129,7 +207,14 @@ func (r *GameController) Tick() {
 
 func (r *GameController) OnNewEvent(event Event) {
 
+	writeReplayEntry(r.stateHistoryFile, ReplayEntryTypeEvent, event)
+
+	r.stateMu.Lock()
 	err := processEvent(&event)
+	if err == nil {
+		r.appendJournalEntry(event)
+	}
+	r.stateMu.Unlock()
 	if err != nil {
 		log.Println("Could not process event:", event, err)
 		return
@@ -141,6 +226,7 @@ func (r *GameController) OnNewEvent(event Event) {
 // Publish publishes the state to the UI and the teams
 func (r *GameController) Publish(command *EventCommand) {
 	if command != nil {
+		writeReplayEntry(r.stateHistoryFile, ReplayEntryTypeCommand, command)
 		RefBox.SaveState()
 	}
 	r.ApiServer.PublishState(*RefBox.State)
@@ -149,52 +235,42 @@ func (r *GameController) Publish(command *EventCommand) {
 
 // SaveState writes the latest state out and logs the state history
 func (r *GameController) SaveState() {
-	r.SaveLatestState()
+	r.saveSnapshot()
 	r.SaveStateHistory()
 }
 
-// SaveLatestState writes the current state into a file
-func (r *GameController) SaveLatestState() {
-	jsonState, err := json.MarshalIndent(r.State, "", "  ")
+// saveSnapshot writes the current state, tagged with the journal cursor it corresponds
+// to, into snapshotFileName so a restart can recover by replaying only the journal suffix.
+func (r *GameController) saveSnapshot() {
+	r.stateMu.Lock()
+	stateCopy, err := cloneState(r.State)
+	cursor := r.journalCursor
+	r.stateMu.Unlock()
 	if err != nil {
-		log.Print("Can not marshal state ", err)
+		log.Print("Can not clone state for snapshot ", err)
 		return
 	}
 
-	err = r.lastStateFile.Truncate(0)
+	jsonSnapshot, err := json.MarshalIndent(journalSnapshot{Cursor: cursor, State: stateCopy}, "", "  ")
 	if err != nil {
-		log.Fatal("Can not truncate last state file ", err)
+		log.Print("Can not marshal snapshot ", err)
+		return
 	}
-	_, err = r.lastStateFile.WriteAt(jsonState, 0)
+
+	err = r.snapshotFile.Truncate(0)
 	if err != nil {
-		log.Print("Could not write last state ", err)
+		log.Fatal("Can not truncate snapshot file ", err)
 	}
-	r.lastStateFile.Sync()
-}
-
-// SaveStateHistory writes the current state to the history file
-func (r *GameController) SaveStateHistory() {
-
-	r.StateHistory = append(r.StateHistory, *r.State)
-
-	jsonState, err := json.Marshal(r.State)
+	_, err = r.snapshotFile.WriteAt(jsonSnapshot, 0)
 	if err != nil {
-		log.Print("Can not marshal state ", err)
-		return
+		log.Print("Could not write snapshot ", err)
 	}
-
-	r.stateHistoryFile.Write(jsonState)
-	r.stateHistoryFile.WriteString("\n")
-	r.stateHistoryFile.Sync()
+	r.snapshotFile.Sync()
 }
 
-// UndoLastAction restores the last state from internal history
-func (r *GameController) UndoLastAction() {
-	lastIndex := len(r.StateHistory) - 2
-	if lastIndex >= 0 {
-		*r.State = r.StateHistory[lastIndex]
-		r.StateHistory = r.StateHistory[0:lastIndex]
-	}
+// SaveStateHistory writes the current state to the NDJSON history/replay file
+func (r *GameController) SaveStateHistory() {
+	writeReplayEntry(r.stateHistoryFile, ReplayEntryTypeState, r.State)
 }
 
 func (r *GameController) loadStages() {
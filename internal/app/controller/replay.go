@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// ReplayEntryType identifies the kind of record stored in a replay log line
+type ReplayEntryType string
+
+const (
+	// ReplayEntryTypeState marks a line that carries a full State snapshot
+	ReplayEntryTypeState ReplayEntryType = "state"
+	// ReplayEntryTypeEvent marks a line that carries an incoming Event
+	ReplayEntryTypeEvent ReplayEntryType = "event"
+	// ReplayEntryTypeCommand marks a line that carries the EventCommand resulting from an Event
+	ReplayEntryTypeCommand ReplayEntryType = "command"
+)
+
+// ReplayEntry is a single NDJSON line of a state-history log file.
+// Entries are written in the order they occur and are replayed in that same order.
+type ReplayEntry struct {
+	T       int64           `json:"t"`
+	Type    ReplayEntryType `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// replayControl carries the pause/step/seek hooks that the ApiServer can use to steer a running Replay
+type replayControl struct {
+	paused bool
+	step   chan struct{}
+	seekTo chan int64
+}
+
+func newReplayControl() *replayControl {
+	return &replayControl{
+		step:   make(chan struct{}, 1),
+		seekTo: make(chan int64, 1),
+	}
+}
+
+// Pause pauses a running replay
+func (r *GameController) Pause() {
+	if r.replay != nil {
+		r.replay.paused = true
+	}
+}
+
+// Resume resumes a paused replay
+func (r *GameController) Resume() {
+	if r.replay != nil {
+		r.replay.paused = false
+	}
+}
+
+// Step advances a paused replay by a single entry
+func (r *GameController) Step() {
+	if r.replay != nil {
+		select {
+		case r.replay.step <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Seek jumps a running replay to the entry at or after timestamp t (unix nanos)
+func (r *GameController) Seek(t int64) {
+	if r.replay != nil {
+		select {
+		case r.replay.seekTo <- t:
+		default:
+		}
+	}
+}
+
+func writeReplayEntry(file *os.File, entryType ReplayEntryType, payload interface{}) {
+	if file == nil {
+		return
+	}
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		log.Print("Can not marshal replay payload ", err)
+		return
+	}
+	entry := ReplayEntry{T: time.Now().UnixNano(), Type: entryType, Payload: jsonPayload}
+	jsonEntry, err := json.Marshal(entry)
+	if err != nil {
+		log.Print("Can not marshal replay entry ", err)
+		return
+	}
+	file.Write(jsonEntry)
+	file.WriteString("\n")
+	file.Sync()
+}
+
+// Replay reads an NDJSON state-history log file and feeds its events back through
+// processEvent at wall-clock-scaled intervals (using r.timer, same as the live tick loop,
+// so tests can inject a fake clock), publishing each result as if it happened live.
+// speed scales the delay between entries (1.0 = original pace, 0 = as fast as possible).
+func (r *GameController) Replay(path string, speed float64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open replay file %v: %v", path, err)
+	}
+	defer f.Close()
+
+	r.replay = newReplayControl()
+	defer func() { r.replay = nil }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var lastT int64
+	var pendingSeek *int64
+	for scanner.Scan() {
+		var entry ReplayEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			log.Println("Could not parse replay entry:", scanner.Text(), err)
+			continue
+		}
+
+		// Only block waiting for Step/Seek when there isn't already a seek in flight -
+		// once pendingSeek is set we want to keep skipping entries towards it below, not
+		// stall again waiting for another manual signal for every entry along the way.
+		if r.replay.paused && pendingSeek == nil {
+			select {
+			case <-r.replay.step:
+			case seekT := <-r.replay.seekTo:
+				pendingSeek = &seekT
+			}
+		}
+		select {
+		case seekT := <-r.replay.seekTo:
+			pendingSeek = &seekT
+		default:
+		}
+		if pendingSeek != nil {
+			if entry.T < *pendingSeek {
+				// Skipping entries towards the seek target; advance lastT alongside them
+				// so the eventual delay calculation below measures from the seek target,
+				// not from the last entry played before the seek arrived.
+				lastT = *pendingSeek
+				continue
+			}
+			pendingSeek = nil
+		}
+
+		if lastT > 0 && speed > 0 {
+			delay := time.Duration(float64(entry.T-lastT) / speed)
+			if delay > 0 {
+				r.timer.Sleep(delay)
+			}
+		}
+		lastT = entry.T
+
+		switch entry.Type {
+		case ReplayEntryTypeEvent:
+			var event Event
+			if err := json.Unmarshal(entry.Payload, &event); err != nil {
+				log.Println("Could not parse replayed event:", err)
+				continue
+			}
+			r.stateMu.Lock()
+			err := processEvent(&event)
+			r.stateMu.Unlock()
+			if err != nil {
+				log.Println("Could not process replayed event:", event, err)
+				continue
+			}
+			r.Publish(event.Command)
+		case ReplayEntryTypeState, ReplayEntryTypeCommand:
+			// informational only, state is reconstructed by replaying events
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,47 @@
+package controller
+
+import "testing"
+
+// TestPauseResumeStepSeekControlReplayState exercises Pause/Resume/Step/Seek against the
+// replayControl a running Replay reads from, without needing a real replay file or state
+// machinery. The Replay loop itself, including how it consumes these signals, is not run here.
+func TestPauseResumeStepSeekControlReplayState(t *testing.T) {
+	r := &GameController{replay: newReplayControl()}
+
+	r.Pause()
+	if !r.replay.paused {
+		t.Fatal("Pause did not set replay.paused")
+	}
+
+	r.Resume()
+	if r.replay.paused {
+		t.Fatal("Resume did not clear replay.paused")
+	}
+
+	r.Step()
+	select {
+	case <-r.replay.step:
+	default:
+		t.Fatal("Step did not send on replay.step")
+	}
+
+	r.Seek(42)
+	select {
+	case seekT := <-r.replay.seekTo:
+		if seekT != 42 {
+			t.Fatalf("Seek sent %v, want 42", seekT)
+		}
+	default:
+		t.Fatal("Seek did not send on replay.seekTo")
+	}
+}
+
+// TestPauseResumeStepSeekNoopWithoutReplay checks that the control methods are safe to call
+// when no replay is running, since the ApiServer can hit these endpoints at any time.
+func TestPauseResumeStepSeekNoopWithoutReplay(t *testing.T) {
+	r := &GameController{}
+	r.Pause()
+	r.Resume()
+	r.Step()
+	r.Seek(1)
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const teamsDir = "teams"
+
+// TeamSettings is the on-disk, per-team roster file read from teams/<teamId>.json.
+type TeamSettings struct {
+	Name     string `json:"name"`
+	Tag      string `json:"tag"`
+	Division string `json:"division"`
+	Color    string `json:"color"`
+}
+
+// watchTeams applies any teams/<teamId>.json files already present, then watches teamsDir
+// for further changes, so a roster staged before Run() starts is picked up without
+// needing to be touched again after startup.
+func (r *GameController) watchTeams() {
+	os.MkdirAll(teamsDir, os.ModePerm)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("Could not start team settings watcher: %v", err)
+		return
+	}
+
+	if err := watcher.Add(teamsDir); err != nil {
+		log.Printf("Could not watch %v: %v", teamsDir, err)
+		return
+	}
+
+	r.scanTeamSettings()
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					r.loadTeamSettings(event.Name)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Team settings watcher error: %v", err)
+			}
+		}
+	}()
+}
+
+// scanTeamSettings applies every teams/*.json file already present in teamsDir.
+func (r *GameController) scanTeamSettings() {
+	paths, err := filepath.Glob(filepath.Join(teamsDir, "*.json"))
+	if err != nil {
+		log.Printf("Could not scan %v: %v", teamsDir, err)
+		return
+	}
+	for _, path := range paths {
+		r.loadTeamSettings(path)
+	}
+}
+
+// loadTeamSettings reads a single teams/<teamId>.json file and applies its display name.
+func (r *GameController) loadTeamSettings(path string) {
+	if filepath.Ext(path) != ".json" {
+		return
+	}
+
+	team, ok := teamFromID(strings.TrimSuffix(filepath.Base(path), ".json"))
+	if !ok {
+		log.Printf("Unknown team id in %v", path)
+		return
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("Could not read team settings %v: %v", path, err)
+		return
+	}
+
+	var settings TeamSettings
+	if err := json.Unmarshal(b, &settings); err != nil {
+		log.Printf("Could not parse team settings %v: %v", path, err)
+		return
+	}
+
+	r.SetTeamName(team, settings.Name)
+}
+
+// teamFromID maps a teams/<teamId>.json file's base name onto a Team.
+func teamFromID(teamId string) (team Team, ok bool) {
+	switch strings.ToUpper(teamId) {
+	case string(TeamYellow):
+		return TeamYellow, true
+	case string(TeamBlue):
+		return TeamBlue, true
+	default:
+		return TeamUnknown, false
+	}
+}
+
+// SetTeamName updates a team's display name and publishes the change so UIs and
+// referees see the rename live, without requiring a restart.
+func (r *GameController) SetTeamName(teamId Team, name string) {
+	r.stateMu.Lock()
+	teamInfo, ok := r.State.TeamState[teamId]
+	if ok {
+		teamInfo.Name = name
+	}
+	r.stateMu.Unlock()
+
+	if !ok {
+		log.Printf("Unknown team %v, can not set name", teamId)
+		return
+	}
+	r.Publish(nil)
+}
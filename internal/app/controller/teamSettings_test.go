@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestGameController() *GameController {
+	return &GameController{
+		State: &State{TeamState: map[Team]*TeamInfo{
+			TeamYellow: {Name: "Yellow"},
+			TeamBlue:   {Name: "Blue"},
+		}},
+	}
+}
+
+// TestSetTeamNameUpdatesKnownTeam checks that SetTeamName updates the in-memory team name
+// referees and UIs read from State.
+func TestSetTeamNameUpdatesKnownTeam(t *testing.T) {
+	r := newTestGameController()
+	r.SetTeamName(TeamYellow, "Tigers")
+	if r.State.TeamState[TeamYellow].Name != "Tigers" {
+		t.Fatalf("got name %q, want %q", r.State.TeamState[TeamYellow].Name, "Tigers")
+	}
+}
+
+// TestSetTeamNameUnknownTeamIsNoop checks that an unrecognized team id is ignored rather
+// than panicking or inserting a new map entry.
+func TestSetTeamNameUnknownTeamIsNoop(t *testing.T) {
+	r := newTestGameController()
+	r.SetTeamName(TeamUnknown, "Ghost")
+	if _, ok := r.State.TeamState[TeamUnknown]; ok {
+		t.Fatal("SetTeamName should not create an entry for an unknown team")
+	}
+}
+
+// TestLoadTeamSettingsAppliesNameFromDisk checks that a single teams/<teamId>.json file is
+// read and applied to the matching team.
+func TestLoadTeamSettingsAppliesNameFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "yellow.json")
+	if err := os.WriteFile(path, []byte(`{"name":"Tigers"}`), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	r := newTestGameController()
+	r.loadTeamSettings(path)
+
+	if r.State.TeamState[TeamYellow].Name != "Tigers" {
+		t.Fatalf("got name %q, want %q", r.State.TeamState[TeamYellow].Name, "Tigers")
+	}
+}
+
+// TestScanTeamSettingsAppliesFilesAlreadyOnDisk reproduces teams/*.json files staged
+// before Run() starts, and checks they are applied without needing a further fsnotify
+// event. Regression test for the missing-initial-scan bug.
+func TestScanTeamSettingsAppliesFilesAlreadyOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, teamsDir), 0700); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, teamsDir, "blue.json"), []byte(`{"name":"Sharks"}`), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	// scanTeamSettings reads teamsDir relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	r := newTestGameController()
+	r.scanTeamSettings()
+
+	if r.State.TeamState[TeamBlue].Name != "Sharks" {
+		t.Fatalf("got name %q, want %q", r.State.TeamState[TeamBlue].Name, "Sharks")
+	}
+}